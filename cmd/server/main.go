@@ -0,0 +1,165 @@
+// Command server runs the simpleblog API: it wires up the database, the
+// HTTP router, and the access log, then serves until it receives SIGINT or
+// SIGTERM, at which point it drains in-flight requests before exiting.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/acme/autocert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"simpleblog/internal/accesslog"
+	"simpleblog/internal/api"
+	"simpleblog/internal/config"
+)
+
+const defaultJWTTTL = 24 * time.Hour
+
+func main() {
+	var configPath string
+	root := &cobra.Command{
+		Use:   "server",
+		Short: "Run the simpleblog API server",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(configPath)
+		},
+	}
+	root.Flags().StringVar(&configPath, "config", "config.yaml", "path to the YAML config file")
+
+	if err := root.Execute(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(configPath string) error {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+
+	sqlDB, err := sql.Open("pgx", cfg.Database.DSN)
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+
+	if err := goose.Up(sqlDB, "migrations"); err != nil {
+		return fmt.Errorf("run migrations: %w", err)
+	}
+
+	gormLogLevel := logger.Info
+	if cfg.Logging.Level == "silent" {
+		gormLogLevel = logger.Silent
+	}
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Default.LogMode(gormLogLevel),
+	})
+	if err != nil {
+		return fmt.Errorf("initialize gorm: %w", err)
+	}
+
+	jwtSecret := []byte(os.Getenv("JWT_SECRET"))
+	if len(jwtSecret) == 0 {
+		return errors.New("JWT_SECRET must be set")
+	}
+	jwtTTL := defaultJWTTTL
+	if ttlStr := os.Getenv("JWT_TTL_MINUTES"); ttlStr != "" {
+		minutes, err := strconv.Atoi(ttlStr)
+		if err != nil {
+			return fmt.Errorf("invalid JWT_TTL_MINUTES: %w", err)
+		}
+		jwtTTL = time.Duration(minutes) * time.Minute
+	}
+
+	router := api.NewRouter(gormDB, jwtSecret, jwtTTL)
+
+	format := cfg.Logging.Format
+	if format == "" {
+		format = accesslog.CommonLogFormat
+	}
+	accessLogger, err := accesslog.New(format, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("invalid logging format: %w", err)
+	}
+
+	srv := &http.Server{
+		Addr:         cfg.Server.Addr,
+		Handler:      accessLogger.Middleware(router),
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+	}
+
+	return serveUntilSignal(srv, cfg.Server)
+}
+
+// serveUntilSignal starts srv in the background and blocks until either it
+// fails outright or the process receives SIGINT/SIGTERM, in which case it
+// gives in-flight requests up to cfg.ShutdownGrace to finish.
+func serveUntilSignal(srv *http.Server, cfg config.ServerConfig) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- listenAndServe(srv, cfg)
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case err := <-serveErr:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("server error: %w", err)
+		}
+		return nil
+	case sig := <-stop:
+		log.Printf("received %s, shutting down (grace period %s)", sig, cfg.ShutdownGrace)
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownGrace)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			return fmt.Errorf("graceful shutdown: %w", err)
+		}
+		if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("server error: %w", err)
+		}
+		return nil
+	}
+}
+
+// listenAndServe picks plain HTTP, static-cert TLS, or autocert based on cfg.
+func listenAndServe(srv *http.Server, cfg config.ServerConfig) error {
+	switch {
+	case cfg.AutocertDomain != "":
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.AutocertDomain),
+			Cache:      autocert.DirCache("certs"),
+		}
+		srv.TLSConfig = manager.TLSConfig()
+		log.Printf("server starting on %s (TLS via autocert for %s)", cfg.Addr, cfg.AutocertDomain)
+		return srv.ListenAndServeTLS("", "")
+	case cfg.TLSCertFile != "" && cfg.TLSKeyFile != "":
+		log.Printf("server starting on %s (TLS)", cfg.Addr)
+		return srv.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+	default:
+		log.Printf("server starting on %s", cfg.Addr)
+		return srv.ListenAndServe()
+	}
+}
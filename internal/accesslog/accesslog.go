@@ -0,0 +1,116 @@
+// Package accesslog provides an HTTP middleware that writes one log line per
+// request, in the spirit of Apache's mod_log_config: a format string made up
+// of literal text and "%"-directives is parsed once into a compiled template,
+// then rendered for every request without re-parsing.
+package accesslog
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CommonLogFormat is the default format, modeled on Apache's Common Log Format.
+const CommonLogFormat = `%h - - [%t] "%r" %s %b`
+
+// Logger renders one log line per request into Out using a pre-compiled
+// Format.
+type Logger struct {
+	Out    io.Writer
+	tokens []token
+}
+
+// New compiles format into a Logger that writes to out. Compilation happens
+// once so that handling a request never re-parses the format string.
+func New(format string, out io.Writer) (*Logger, error) {
+	tokens, err := compile(format)
+	if err != nil {
+		return nil, err
+	}
+	return &Logger{Out: out, tokens: tokens}, nil
+}
+
+// Middleware wraps next so that every request it handles is logged once it
+// completes.
+func (l *Logger) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rw, r)
+
+		rec := record{
+			req:      r,
+			start:    start,
+			status:   rw.status,
+			bytes:    rw.bytes,
+			duration: time.Since(start),
+			respHdr:  rw.Header(),
+		}
+		l.render(rec)
+	})
+}
+
+func (l *Logger) render(rec record) {
+	var b strings.Builder
+	for _, tok := range l.tokens {
+		tok.write(&b, rec)
+	}
+	b.WriteByte('\n')
+	fmt.Fprint(l.Out, b.String())
+}
+
+// record holds everything a directive might need to render itself.
+type record struct {
+	req      *http.Request
+	start    time.Time
+	status   int
+	bytes    int
+	duration time.Duration
+	respHdr  http.Header
+}
+
+// responseWriter wraps http.ResponseWriter to capture the status code and the
+// number of bytes written, while passing Flusher/Hijacker through to the
+// underlying writer so streaming handlers keep working unchanged.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += n
+	return n, err
+}
+
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("accesslog: underlying ResponseWriter does not implement http.Hijacker")
+	}
+	return hj.Hijack()
+}
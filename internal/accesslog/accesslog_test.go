@@ -0,0 +1,63 @@
+package accesslog
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewareCommonLogFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(CommonLogFormat, &buf)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	handler := logger.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("hello"))
+	}))
+
+	req := httptest.NewRequest("GET", "/departments/1", nil)
+	req.RemoteAddr = "203.0.113.5:54321"
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	line := buf.String()
+	if !strings.HasPrefix(line, "203.0.113.5 - - [") {
+		t.Errorf("expected line to start with remote host, got %q", line)
+	}
+	if !strings.Contains(line, `"GET /departments/1 HTTP/1.1" 201 5`) {
+		t.Errorf("expected request line, status and byte count, got %q", line)
+	}
+}
+
+func TestMiddlewareCustomHeaders(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(`%{X-Request-ID}i %{X-Served-By}o`, &buf)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	handler := logger.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Served-By", "api-1")
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Request-ID", "abc-123")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := buf.String(); got != "abc-123 api-1\n" {
+		t.Errorf("got %q, want %q", got, "abc-123 api-1\n")
+	}
+}
+
+func TestCompileRejectsUnknownDirective(t *testing.T) {
+	if _, err := compile("%q"); err == nil {
+		t.Error("expected error for unknown directive, got nil")
+	}
+}
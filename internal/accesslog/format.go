@@ -0,0 +1,132 @@
+package accesslog
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// apacheTimeFormat mirrors Apache's default "%t" layout, e.g. 10/Oct/2000:13:55:36 -0700.
+const apacheTimeFormat = "02/Jan/2006:15:04:05 -0700"
+
+// token renders one piece of a compiled format: either a literal run of text
+// or a "%" directive.
+type token interface {
+	write(b *strings.Builder, rec record)
+}
+
+type literalToken string
+
+func (t literalToken) write(b *strings.Builder, _ record) {
+	b.WriteString(string(t))
+}
+
+type funcToken func(rec record) string
+
+func (t funcToken) write(b *strings.Builder, rec record) {
+	b.WriteString(t(rec))
+}
+
+// compile parses format once into a slice of tokens so that rendering a
+// request never has to re-parse the directive string.
+func compile(format string) ([]token, error) {
+	var tokens []token
+	var lit strings.Builder
+
+	flush := func() {
+		if lit.Len() > 0 {
+			tokens = append(tokens, literalToken(lit.String()))
+			lit.Reset()
+		}
+	}
+
+	runes := []rune(format)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			lit.WriteRune(runes[i])
+			continue
+		}
+		if i+1 >= len(runes) {
+			return nil, fmt.Errorf("accesslog: trailing %% in format %q", format)
+		}
+		i++
+		if runes[i] == '%' {
+			lit.WriteRune('%')
+			continue
+		}
+		// %{Header-Name}i / %{Header-Name}o
+		if runes[i] == '{' {
+			end := strings.IndexRune(string(runes[i:]), '}')
+			if end == -1 {
+				return nil, fmt.Errorf("accesslog: unterminated %%{ in format %q", format)
+			}
+			name := string(runes[i+1 : i+end])
+			i += end + 1
+			if i >= len(runes) {
+				return nil, fmt.Errorf("accesslog: %%{%s} missing i/o selector in format %q", name, format)
+			}
+			var fn funcToken
+			switch runes[i] {
+			case 'i':
+				fn = requestHeaderToken(name)
+			case 'o':
+				fn = responseHeaderToken(name)
+			default:
+				return nil, fmt.Errorf("accesslog: unknown selector %%{%s}%c in format %q", name, runes[i], format)
+			}
+			flush()
+			tokens = append(tokens, fn)
+			continue
+		}
+		fn, ok := directives[runes[i]]
+		if !ok {
+			return nil, fmt.Errorf("accesslog: unknown directive %%%c in format %q", runes[i], format)
+		}
+		flush()
+		tokens = append(tokens, fn)
+	}
+	flush()
+	return tokens, nil
+}
+
+// directives maps a single-letter directive to the function that renders it.
+var directives = map[rune]funcToken{
+	'h': func(rec record) string { return remoteHost(rec.req) },
+	't': func(rec record) string { return rec.start.Format(apacheTimeFormat) },
+	'r': func(rec record) string {
+		return fmt.Sprintf("%s %s %s", rec.req.Method, rec.req.URL.RequestURI(), rec.req.Proto)
+	},
+	's': func(rec record) string { return strconv.Itoa(rec.status) },
+	'b': func(rec record) string { return strconv.Itoa(rec.bytes) },
+	'D': func(rec record) string { return strconv.FormatInt(rec.duration.Microseconds(), 10) },
+}
+
+func requestHeaderToken(name string) funcToken {
+	return func(rec record) string {
+		v := rec.req.Header.Get(name)
+		if v == "" {
+			return "-"
+		}
+		return v
+	}
+}
+
+func responseHeaderToken(name string) funcToken {
+	return func(rec record) string {
+		v := rec.respHdr.Get(name)
+		if v == "" {
+			return "-"
+		}
+		return v
+	}
+}
+
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"simpleblog/internal/auth"
+)
+
+var (
+	errMissingOrganization   = errors.New("organization id not provided: set X-Organization-ID header")
+	errBadOrganizationHeader = errors.New("X-Organization-ID must be an integer")
+)
+
+// contextKey avoids collisions with context keys set by other packages.
+type contextKey string
+
+const userIDContextKey contextKey = "user_id"
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type LoginResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// login authenticates a username/password pair and issues a JWT.
+func login(db *gorm.DB, secret []byte, ttl time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req LoginRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		var user User
+		if err := db.Preload("Organizations").Where("username = ?", req.Username).First(&user).Error; err != nil {
+			respondError(w, http.StatusUnauthorized, "invalid username or password")
+			return
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+			respondError(w, http.StatusUnauthorized, "invalid username or password")
+			return
+		}
+		// Stamp the token with the user's organization only when it's
+		// unambiguous; users in more than one organization must select via
+		// the X-Organization-ID header on each request.
+		var orgID int
+		if len(user.Organizations) == 1 {
+			orgID = user.Organizations[0].ID
+		}
+		token, err := auth.Issue(secret, user.ID, orgID, ttl)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "failed to issue token")
+			return
+		}
+		respondJSON(w, http.StatusOK, LoginResponse{Token: token, ExpiresAt: time.Now().Add(ttl)})
+	}
+}
+
+// RequirePermission wraps next so that requests must carry a valid bearer
+// token for a user whose roles grant permission, otherwise it responds with
+// 401 (missing/invalid token) or 403 (valid token, insufficient permission).
+// It then resolves the caller's organization via organizationIDFromRequest,
+// responding 400 if none is given or the header is malformed and 403 if the
+// user is not a member, and passes the user and organization IDs on to next
+// in the request context.
+func RequirePermission(db *gorm.DB, secret []byte, permission string) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			tokenString, ok := bearerToken(r)
+			if !ok {
+				respondError(w, http.StatusUnauthorized, "missing bearer token")
+				return
+			}
+			claims, err := auth.Parse(secret, tokenString)
+			if err != nil {
+				respondError(w, http.StatusUnauthorized, "invalid or expired token")
+				return
+			}
+			var user User
+			if err := db.Preload("Roles").First(&user, claims.UserID).Error; err != nil {
+				respondError(w, http.StatusUnauthorized, "invalid or expired token")
+				return
+			}
+			if !userHasPermission(user, permission) {
+				respondError(w, http.StatusForbidden, "insufficient permissions")
+				return
+			}
+			orgID, err := organizationIDFromRequest(r, claims)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			belongs, err := userBelongsToOrganization(db, user.ID, orgID)
+			if err != nil {
+				respondError(w, http.StatusInternalServerError, "database error")
+				return
+			}
+			if !belongs {
+				respondError(w, http.StatusForbidden, "user does not belong to this organization")
+				return
+			}
+			ctx := context.WithValue(r.Context(), userIDContextKey, user.ID)
+			ctx = context.WithValue(ctx, organizationIDContextKey, orgID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+	}
+}
+
+func userHasPermission(user User, permission string) bool {
+	for _, role := range user.Roles {
+		if role.Permissions.Has(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// organizationIDFromRequest resolves the caller's tenant, preferring the
+// X-Organization-ID header and falling back to the token's own claim.
+func organizationIDFromRequest(r *http.Request, claims *auth.Claims) (int, error) {
+	if header := r.Header.Get("X-Organization-ID"); header != "" {
+		orgID, err := strconv.Atoi(header)
+		if err != nil {
+			return 0, errBadOrganizationHeader
+		}
+		return orgID, nil
+	}
+	if claims.OrganizationID != 0 {
+		return claims.OrganizationID, nil
+	}
+	return 0, errMissingOrganization
+}
+
+// userBelongsToOrganization reports whether userID is a member of orgID, so
+// RequirePermission can reject a caller-supplied X-Organization-ID that the
+// token's user has no actual membership in.
+func userBelongsToOrganization(db *gorm.DB, userID, orgID int) (bool, error) {
+	var count int64
+	err := db.Table("user_organizations").Where("user_id = ? AND organization_id = ?", userID, orgID).Count(&count).Error
+	return count > 0, err
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(header, prefix), true
+}
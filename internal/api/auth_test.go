@@ -0,0 +1,130 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"simpleblog/internal/auth"
+)
+
+var testJWTSecret = []byte("test-secret")
+
+func setupAuthTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	err = db.AutoMigrate(&Organization{}, &Department{}, &User{}, &Role{})
+	assert.NoError(t, err)
+	return db
+}
+
+func createTestUser(t *testing.T, db *gorm.DB, username string, permissions Permissions, orgs ...Organization) User {
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	user := User{Username: username, PasswordHash: string(hash)}
+	assert.NoError(t, db.Create(&user).Error)
+
+	role := Role{Name: username + "-role", Permissions: permissions}
+	assert.NoError(t, db.Create(&role).Error)
+	assert.NoError(t, db.Model(&user).Association("Roles").Append(&role))
+
+	for i := range orgs {
+		assert.NoError(t, db.Model(&user).Association("Organizations").Append(&orgs[i]))
+	}
+	return user
+}
+
+func TestRequirePermissionMissingToken(t *testing.T) {
+	db := setupAuthTestDB(t)
+	handler := RequirePermission(db, testJWTSecret, "departments:read")(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not be called")
+	})
+
+	req := httptest.NewRequest("GET", "/departments/1", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestRequirePermissionExpiredToken(t *testing.T) {
+	db := setupAuthTestDB(t)
+	org := Organization{Name: "Acme"}
+	assert.NoError(t, db.Create(&org).Error)
+	user := createTestUser(t, db, "expired-user", Permissions{"departments:read"}, org)
+
+	token, err := auth.Issue(testJWTSecret, user.ID, org.ID, -time.Hour)
+	assert.NoError(t, err)
+
+	handler := RequirePermission(db, testJWTSecret, "departments:read")(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not be called")
+	})
+	req := httptest.NewRequest("GET", "/departments/1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestRequirePermissionInsufficientPermission(t *testing.T) {
+	db := setupAuthTestDB(t)
+	org := Organization{Name: "Acme"}
+	assert.NoError(t, db.Create(&org).Error)
+	user := createTestUser(t, db, "readonly-user", Permissions{"departments:read"}, org)
+
+	token, err := auth.Issue(testJWTSecret, user.ID, org.ID, time.Hour)
+	assert.NoError(t, err)
+
+	handler := RequirePermission(db, testJWTSecret, "departments:delete")(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not be called")
+	})
+	req := httptest.NewRequest("DELETE", "/departments/1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestRequirePermissionRejectsForeignOrganizationHeader(t *testing.T) {
+	db := setupAuthTestDB(t)
+	ownOrg := Organization{Name: "Acme"}
+	otherOrg := Organization{Name: "Globex"}
+	assert.NoError(t, db.Create(&ownOrg).Error)
+	assert.NoError(t, db.Create(&otherOrg).Error)
+	user := createTestUser(t, db, "tenant-user", Permissions{"departments:read"}, ownOrg)
+
+	token, err := auth.Issue(testJWTSecret, user.ID, ownOrg.ID, time.Hour)
+	assert.NoError(t, err)
+
+	var gotOrgID int
+	handler := RequirePermission(db, testJWTSecret, "departments:read")(func(w http.ResponseWriter, r *http.Request) {
+		gotOrgID = organizationIDFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Claiming another tenant's org via the header must be rejected even
+	// though the token itself is valid and carries the right permission.
+	req := httptest.NewRequest("GET", "/departments/1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("X-Organization-ID", strconv.Itoa(otherOrg.ID))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// The token's own organization still works.
+	req = httptest.NewRequest("GET", "/departments/1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, ownOrg.ID, gotOrgID)
+}
@@ -0,0 +1,885 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Request/Response структуры
+type CreateDepartmentRequest struct {
+	Name     string `json:"name"`
+	ParentID *int   `json:"parent_id"`
+}
+
+type UpdateDepartmentRequest struct {
+	Name     *string `json:"name"`
+	ParentID *int    `json:"parent_id"`
+}
+
+type CreateEmployeeRequest struct {
+	FullName   string     `json:"full_name"`
+	PositionID int        `json:"position_id"`
+	HiredAt    *time.Time `json:"hired_at"`
+}
+
+type UpdateEmployeeRequest struct {
+	FullName     *string `json:"full_name"`
+	DepartmentID *int    `json:"department_id"`
+	PositionID   *int    `json:"position_id"`
+}
+
+type CreatePositionRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type UpdatePositionRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+type ArchiveDepartmentRequest struct {
+	Reason string `json:"reason"`
+}
+
+type DepartmentResponse struct {
+	ID        int                `json:"id"`
+	Name      string             `json:"name"`
+	ParentID  *int               `json:"parent_id"`
+	CreatedAt time.Time          `json:"created_at"`
+	Employees []EmployeeResponse  `json:"employees,omitempty"`
+	Children  []DepartmentResponse `json:"children,omitempty"`
+}
+
+type EmployeeResponse struct {
+	ID           int        `json:"id"`
+	DepartmentID int        `json:"department_id"`
+	FullName     string     `json:"full_name"`
+	PositionID   int        `json:"position_id"`
+	HiredAt      *time.Time `json:"hired_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// Утилиты для ответов
+func respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func respondError(w http.ResponseWriter, status int, message string) {
+	respondJSON(w, status, map[string]string{"error": message})
+}
+
+// 1) Создать подразделение
+func createDepartment(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CreateDepartmentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		if req.ParentID != nil {
+			var parent Department
+			if err := ScopedDB(r.Context(), db).First(&parent, *req.ParentID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					respondError(w, http.StatusNotFound, "parent department not found")
+				} else {
+					respondError(w, http.StatusInternalServerError, "database error")
+				}
+				return
+			}
+		}
+		dept := Department{
+			OrganizationID: organizationIDFromContext(r.Context()),
+			Name:           req.Name,
+			ParentID:       req.ParentID,
+		}
+		if err := db.Create(&dept).Error; err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondJSON(w, http.StatusCreated, dept)
+	}
+}
+
+// 2) Создать сотрудника в подразделении
+func createEmployee(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := r.PathValue("id")
+		deptID, err := strconv.Atoi(idStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid department id")
+			return
+		}
+		var dept Department
+		if err := ScopedDB(r.Context(), db).First(&dept, deptID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				respondError(w, http.StatusNotFound, "department not found")
+			} else {
+				respondError(w, http.StatusInternalServerError, "database error")
+			}
+			return
+		}
+		var req CreateEmployeeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		var position Position
+		if err := db.First(&position, req.PositionID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				respondError(w, http.StatusNotFound, "position not found")
+			} else {
+				respondError(w, http.StatusInternalServerError, "database error")
+			}
+			return
+		}
+		emp := Employee{
+			OrganizationID: organizationIDFromContext(r.Context()),
+			DepartmentID:   deptID,
+			FullName:       req.FullName,
+			PositionID:     req.PositionID,
+			HiredAt:        req.HiredAt,
+		}
+		tx := db.Begin()
+		if err := tx.Create(&emp).Error; err != nil {
+			tx.Rollback()
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		assignment := EmployeeAssignment{
+			EmployeeID:   emp.ID,
+			DepartmentID: emp.DepartmentID,
+			PositionID:   emp.PositionID,
+			StartedAt:    time.Now(),
+		}
+		if err := tx.Create(&assignment).Error; err != nil {
+			tx.Rollback()
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if err := tx.Commit().Error; err != nil {
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		respondJSON(w, http.StatusCreated, emp)
+	}
+}
+
+// 3) Получить подразделение (детали + сотрудники + поддерево)
+func getDepartment(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := r.PathValue("id")
+		deptID, err := strconv.Atoi(idStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid department id")
+			return
+		}
+
+		// Парсинг query-параметров
+		depth := 1
+		if d := r.URL.Query().Get("depth"); d != "" {
+			if val, err := strconv.Atoi(d); err == nil && val >= 1 && val <= 5 {
+				depth = val
+			} else {
+				respondError(w, http.StatusBadRequest, "depth must be integer between 1 and 5")
+				return
+			}
+		}
+		includeEmployees := true
+		if ie := r.URL.Query().Get("include_employees"); ie != "" {
+			if val, err := strconv.ParseBool(ie); err == nil {
+				includeEmployees = val
+			} else {
+				respondError(w, http.StatusBadRequest, "include_employees must be boolean")
+				return
+			}
+		}
+		sortBy := r.URL.Query().Get("sort_employees")
+		if sortBy == "" {
+			sortBy = "full_name"
+		} else if sortBy != "full_name" && sortBy != "created_at" {
+			respondError(w, http.StatusBadRequest, "sort_employees must be 'full_name' or 'created_at'")
+			return
+		}
+		includeArchived := false
+		if ia := r.URL.Query().Get("include_archived"); ia != "" {
+			if val, err := strconv.ParseBool(ia); err == nil {
+				includeArchived = val
+			} else {
+				respondError(w, http.StatusBadRequest, "include_archived must be boolean")
+				return
+			}
+		}
+
+		// newScoped returns a fresh org-scoped query each call - GORM
+		// accumulates conditions onto a *gorm.DB across chained calls, so a
+		// single shared instance would leak earlier Where clauses into later,
+		// unrelated queries.
+		newScoped := func() *gorm.DB {
+			s := ScopedDB(r.Context(), db)
+			if includeArchived {
+				s = s.Unscoped()
+			}
+			return s
+		}
+
+		// Загружаем корневой отдел
+		var rootDept Department
+		if err := newScoped().First(&rootDept, deptID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				respondError(w, http.StatusNotFound, "department not found")
+			} else {
+				respondError(w, http.StatusInternalServerError, "database error")
+			}
+			return
+		}
+
+		// Строим дерево в памяти (максимум depth уровней)
+		type deptNode struct {
+			Department
+			Level int
+		}
+		deptMap := map[int]*deptNode{rootDept.ID: {Department: rootDept, Level: 0}}
+		currentIDs := []int{rootDept.ID}
+
+		for level := 1; level <= depth; level++ {
+			if len(currentIDs) == 0 {
+				break
+			}
+			var children []Department
+			if err := newScoped().Where("parent_id IN ?", currentIDs).Find(&children).Error; err != nil {
+				respondError(w, http.StatusInternalServerError, "database error")
+				return
+			}
+			nextIDs := make([]int, 0, len(children))
+			for _, child := range children {
+				deptMap[child.ID] = &deptNode{Department: child, Level: level}
+				nextIDs = append(nextIDs, child.ID)
+			}
+			currentIDs = nextIDs
+		}
+
+		// Связываем родителей и детей
+		for _, node := range deptMap {
+			if node.ParentID != nil {
+				if parent, ok := deptMap[*node.ParentID]; ok {
+					parent.Children = append(parent.Children, node.Department)
+				}
+			}
+		}
+
+		// Загружаем сотрудников, если нужно
+		if includeEmployees {
+			deptIDs := make([]int, 0, len(deptMap))
+			for id := range deptMap {
+				deptIDs = append(deptIDs, id)
+			}
+			var employees []Employee
+			query := newScoped().Where("department_id IN ?", deptIDs)
+			if sortBy == "full_name" {
+				query = query.Order("full_name")
+			} else {
+				query = query.Order("created_at")
+			}
+			if err := query.Find(&employees).Error; err != nil {
+				respondError(w, http.StatusInternalServerError, "database error")
+				return
+			}
+			empMap := make(map[int][]Employee)
+			for _, emp := range employees {
+				empMap[emp.DepartmentID] = append(empMap[emp.DepartmentID], emp)
+			}
+			for _, node := range deptMap {
+				node.Employees = empMap[node.ID]
+			}
+		}
+
+		// Рекурсивное преобразование в ответ
+		var toResponse func(dept *Department, currentDepth int) DepartmentResponse
+		toResponse = func(dept *Department, currentDepth int) DepartmentResponse {
+			resp := DepartmentResponse{
+				ID:        dept.ID,
+				Name:      dept.Name,
+				ParentID:  dept.ParentID,
+				CreatedAt: dept.CreatedAt,
+			}
+			if includeEmployees {
+				for _, emp := range dept.Employees {
+					resp.Employees = append(resp.Employees, EmployeeResponse{
+						ID:           emp.ID,
+						DepartmentID: emp.DepartmentID,
+						FullName:     emp.FullName,
+						PositionID:   emp.PositionID,
+						HiredAt:      emp.HiredAt,
+						CreatedAt:    emp.CreatedAt,
+					})
+				}
+			}
+			if currentDepth < depth {
+				for _, child := range dept.Children {
+					resp.Children = append(resp.Children, toResponse(&child, currentDepth+1))
+				}
+			}
+			return resp
+		}
+
+		respondJSON(w, http.StatusOK, toResponse(&rootDept, 0))
+	}
+}
+
+// 4) Переместить подразделение (обновить)
+func updateDepartment(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := r.PathValue("id")
+		deptID, err := strconv.Atoi(idStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid department id")
+			return
+		}
+		var req UpdateDepartmentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		var dept Department
+		if err := ScopedDB(r.Context(), db).First(&dept, deptID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				respondError(w, http.StatusNotFound, "department not found")
+			} else {
+				respondError(w, http.StatusInternalServerError, "database error")
+			}
+			return
+		}
+		if req.ParentID != nil {
+			// Проверяем существование нового родителя
+			if *req.ParentID != 0 {
+				var parent Department
+				if err := ScopedDB(r.Context(), db).First(&parent, *req.ParentID).Error; err != nil {
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						respondError(w, http.StatusNotFound, "parent department not found")
+					} else {
+						respondError(w, http.StatusInternalServerError, "database error")
+					}
+					return
+				}
+			}
+			// Проверка цикла
+			if err := dept.CheckCycle(db, req.ParentID); err != nil {
+				respondError(w, http.StatusConflict, err.Error())
+				return
+			}
+			dept.ParentID = req.ParentID
+		}
+		if req.Name != nil {
+			dept.Name = *req.Name
+		}
+		if err := db.Save(&dept).Error; err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondJSON(w, http.StatusOK, dept)
+	}
+}
+
+// 5) Удалить подразделение
+func deleteDepartment(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := r.PathValue("id")
+		deptID, err := strconv.Atoi(idStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid department id")
+			return
+		}
+		mode := r.URL.Query().Get("mode")
+		if mode == "" {
+			mode = "cascade" // значение по умолчанию
+		}
+		reassignToStr := r.URL.Query().Get("reassign_to_department_id")
+		var reassignTo *int
+		if reassignToStr != "" {
+			val, err := strconv.Atoi(reassignToStr)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "invalid reassign_to_department_id")
+				return
+			}
+			reassignTo = &val
+		}
+		if mode == "reassign" && reassignTo == nil {
+			respondError(w, http.StatusBadRequest, "reassign_to_department_id is required for reassign mode")
+			return
+		}
+		force, err := strconv.ParseBool(r.URL.Query().Get("force"))
+		if err != nil {
+			force = false
+		}
+		if !force {
+			respondError(w, http.StatusBadRequest, "DELETE permanently purges data; pass ?force=true, or use POST /departments/{id}/archive for a reversible delete")
+			return
+		}
+		var dept Department
+		if err := ScopedDB(r.Context(), db).First(&dept, deptID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				respondError(w, http.StatusNotFound, "department not found")
+			} else {
+				respondError(w, http.StatusInternalServerError, "database error")
+			}
+			return
+		}
+		// Транзакция
+		tx := db.Begin()
+		defer func() {
+			if r := recover(); r != nil {
+				tx.Rollback()
+			}
+		}()
+		if err := tx.Error; err != nil {
+			respondError(w, http.StatusInternalServerError, "transaction error")
+			return
+		}
+		if mode == "cascade" {
+			// Каскадное удаление через внешние ключи (ON DELETE CASCADE)
+			if err := tx.Unscoped().Delete(&dept).Error; err != nil {
+				tx.Rollback()
+				respondError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+		} else if mode == "reassign" {
+			// Проверяем существование целевого отдела
+			var targetDept Department
+			if err := tx.First(&targetDept, *reassignTo).Error; err != nil {
+				tx.Rollback()
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					respondError(w, http.StatusNotFound, "reassign target department not found")
+				} else {
+					respondError(w, http.StatusInternalServerError, "database error")
+				}
+				return
+			}
+			// Отдел назначения должен принадлежать той же организации
+			if targetDept.OrganizationID != dept.OrganizationID {
+				tx.Rollback()
+				respondError(w, http.StatusConflict, "reassign target department belongs to a different organization")
+				return
+			}
+			// Переназначаем сотрудников
+			if err := tx.Model(&Employee{}).Where("department_id = ?", deptID).Update("department_id", *reassignTo).Error; err != nil {
+				tx.Rollback()
+				respondError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			// Переназначаем дочерние подразделения
+			if err := tx.Model(&Department{}).Where("parent_id = ?", deptID).Update("parent_id", *reassignTo).Error; err != nil {
+				tx.Rollback()
+				respondError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			// Удаляем сам отдел
+			if err := tx.Unscoped().Delete(&dept).Error; err != nil {
+				tx.Rollback()
+				respondError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+		} else {
+			respondError(w, http.StatusBadRequest, "mode must be 'cascade' or 'reassign'")
+			return
+		}
+		if err := tx.Commit().Error; err != nil {
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// 6) Обновить сотрудника (ФИО, подразделение, должность)
+func updateEmployee(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := r.PathValue("id")
+		empID, err := strconv.Atoi(idStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid employee id")
+			return
+		}
+		var req UpdateEmployeeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		var emp Employee
+		if err := ScopedDB(r.Context(), db).First(&emp, empID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				respondError(w, http.StatusNotFound, "employee not found")
+			} else {
+				respondError(w, http.StatusInternalServerError, "database error")
+			}
+			return
+		}
+		newDeptID := emp.DepartmentID
+		if req.DepartmentID != nil {
+			var dept Department
+			if err := ScopedDB(r.Context(), db).First(&dept, *req.DepartmentID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					respondError(w, http.StatusNotFound, "department not found")
+				} else {
+					respondError(w, http.StatusInternalServerError, "database error")
+				}
+				return
+			}
+			newDeptID = dept.ID
+		}
+		newPositionID := emp.PositionID
+		if req.PositionID != nil {
+			var position Position
+			if err := db.First(&position, *req.PositionID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					respondError(w, http.StatusNotFound, "position not found")
+				} else {
+					respondError(w, http.StatusInternalServerError, "database error")
+				}
+				return
+			}
+			newPositionID = position.ID
+		}
+		if req.FullName != nil {
+			emp.FullName = *req.FullName
+		}
+		reassigned := newDeptID != emp.DepartmentID || newPositionID != emp.PositionID
+		emp.DepartmentID = newDeptID
+		emp.PositionID = newPositionID
+
+		tx := db.Begin()
+		if reassigned {
+			now := time.Now()
+			if err := tx.Model(&EmployeeAssignment{}).
+				Where("employee_id = ? AND ended_at IS NULL", emp.ID).
+				Update("ended_at", now).Error; err != nil {
+				tx.Rollback()
+				respondError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			assignment := EmployeeAssignment{
+				EmployeeID:   emp.ID,
+				DepartmentID: emp.DepartmentID,
+				PositionID:   emp.PositionID,
+				StartedAt:    now,
+			}
+			if err := tx.Create(&assignment).Error; err != nil {
+				tx.Rollback()
+				respondError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+		if err := tx.Save(&emp).Error; err != nil {
+			tx.Rollback()
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := tx.Commit().Error; err != nil {
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		respondJSON(w, http.StatusOK, emp)
+	}
+}
+
+// 7) История назначений сотрудника
+func getEmployeeHistory(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := r.PathValue("id")
+		empID, err := strconv.Atoi(idStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid employee id")
+			return
+		}
+		var emp Employee
+		if err := ScopedDB(r.Context(), db).First(&emp, empID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				respondError(w, http.StatusNotFound, "employee not found")
+			} else {
+				respondError(w, http.StatusInternalServerError, "database error")
+			}
+			return
+		}
+		var history []EmployeeAssignment
+		if err := db.Where("employee_id = ?", emp.ID).Order("started_at").Find(&history).Error; err != nil {
+			respondError(w, http.StatusInternalServerError, "database error")
+			return
+		}
+		respondJSON(w, http.StatusOK, history)
+	}
+}
+
+// 8) Создать должность
+func createPosition(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CreatePositionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		position := Position{Name: req.Name, Description: req.Description}
+		if err := db.Create(&position).Error; err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondJSON(w, http.StatusCreated, position)
+	}
+}
+
+// 9) Список должностей
+func listPositions(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var positions []Position
+		if err := db.Order("name").Find(&positions).Error; err != nil {
+			respondError(w, http.StatusInternalServerError, "database error")
+			return
+		}
+		respondJSON(w, http.StatusOK, positions)
+	}
+}
+
+// 10) Обновить должность
+func updatePosition(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := r.PathValue("id")
+		positionID, err := strconv.Atoi(idStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid position id")
+			return
+		}
+		var req UpdatePositionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		var position Position
+		if err := db.First(&position, positionID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				respondError(w, http.StatusNotFound, "position not found")
+			} else {
+				respondError(w, http.StatusInternalServerError, "database error")
+			}
+			return
+		}
+		if req.Name != nil {
+			position.Name = *req.Name
+		}
+		if req.Description != nil {
+			position.Description = *req.Description
+		}
+		if err := db.Save(&position).Error; err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondJSON(w, http.StatusOK, position)
+	}
+}
+
+// 11) Удалить должность
+func deletePosition(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := r.PathValue("id")
+		positionID, err := strconv.Atoi(idStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid position id")
+			return
+		}
+		var position Position
+		if err := db.First(&position, positionID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				respondError(w, http.StatusNotFound, "position not found")
+			} else {
+				respondError(w, http.StatusInternalServerError, "database error")
+			}
+			return
+		}
+		var count int64
+		if err := db.Model(&Employee{}).Where("position_id = ?", positionID).Count(&count).Error; err != nil {
+			respondError(w, http.StatusInternalServerError, "database error")
+			return
+		}
+		if count > 0 {
+			respondError(w, http.StatusConflict, "position is still assigned to employees")
+			return
+		}
+		if err := db.Delete(&position).Error; err != nil {
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// departmentSubtreeIDs returns deptID and every descendant's ID, including
+// archived ones, so archive/restore act on the whole subtree at once.
+func departmentSubtreeIDs(db *gorm.DB, deptID int) ([]int, error) {
+	// Session makes each query below start from db's own conditions instead
+	// of piling its parent_id filter onto the previous iteration's.
+	db = db.Session(&gorm.Session{})
+	ids := []int{deptID}
+	queue := []int{deptID}
+	for len(queue) > 0 {
+		var children []int
+		if err := db.Unscoped().Model(&Department{}).Where("parent_id = ?", queue[0]).Pluck("id", &children).Error; err != nil {
+			return nil, err
+		}
+		queue = queue[1:]
+		ids = append(ids, children...)
+		queue = append(queue, children...)
+	}
+	return ids, nil
+}
+
+// 12) Архивировать подразделение (и поддерево)
+func archiveDepartment(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := r.PathValue("id")
+		deptID, err := strconv.Atoi(idStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid department id")
+			return
+		}
+		var req ArchiveDepartmentRequest
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				respondError(w, http.StatusBadRequest, "invalid request body")
+				return
+			}
+		}
+		var dept Department
+		if err := ScopedDB(r.Context(), db).First(&dept, deptID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				respondError(w, http.StatusNotFound, "department not found")
+			} else {
+				respondError(w, http.StatusInternalServerError, "database error")
+			}
+			return
+		}
+		deptIDs, err := departmentSubtreeIDs(ScopedDB(r.Context(), db), dept.ID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "database error")
+			return
+		}
+		var empIDs []int
+		if err := ScopedDB(r.Context(), db).Model(&Employee{}).Where("department_id IN ?", deptIDs).Pluck("id", &empIDs).Error; err != nil {
+			respondError(w, http.StatusInternalServerError, "database error")
+			return
+		}
+
+		archivedBy := userIDFromContext(r.Context())
+		now := time.Now()
+		tx := db.Begin()
+		if err := tx.Where("id IN ?", deptIDs).Delete(&Department{}).Error; err != nil {
+			tx.Rollback()
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if len(empIDs) > 0 {
+			if err := tx.Where("id IN ?", empIDs).Delete(&Employee{}).Error; err != nil {
+				tx.Rollback()
+				respondError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+		events := make([]ArchiveEvent, 0, len(deptIDs)+len(empIDs))
+		for _, id := range deptIDs {
+			events = append(events, ArchiveEvent{EntityType: "department", EntityID: id, ArchivedBy: archivedBy, ArchivedAt: now, Reason: req.Reason})
+		}
+		for _, id := range empIDs {
+			events = append(events, ArchiveEvent{EntityType: "employee", EntityID: id, ArchivedBy: archivedBy, ArchivedAt: now, Reason: req.Reason})
+		}
+		if len(events) > 0 {
+			if err := tx.Create(&events).Error; err != nil {
+				tx.Rollback()
+				respondError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+		if err := tx.Commit().Error; err != nil {
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		dept.DeletedAt = gorm.DeletedAt{Time: now, Valid: true}
+		respondJSON(w, http.StatusOK, dept)
+	}
+}
+
+// 13) Восстановить подразделение (и поддерево)
+func restoreDepartment(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idStr := r.PathValue("id")
+		deptID, err := strconv.Atoi(idStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid department id")
+			return
+		}
+		var dept Department
+		if err := ScopedDB(r.Context(), db).Unscoped().First(&dept, deptID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				respondError(w, http.StatusNotFound, "department not found")
+			} else {
+				respondError(w, http.StatusInternalServerError, "database error")
+			}
+			return
+		}
+		if !dept.DeletedAt.Valid {
+			respondError(w, http.StatusBadRequest, "department is not archived")
+			return
+		}
+		if dept.ParentID != nil {
+			var parent Department
+			if err := ScopedDB(r.Context(), db).First(&parent, *dept.ParentID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					respondError(w, http.StatusConflict, "cannot restore: parent department is archived or missing")
+					return
+				}
+				respondError(w, http.StatusInternalServerError, "database error")
+				return
+			}
+		}
+		// Повторная проверка валидации (уникальность имени среди активных отделов, нет циклов)
+		if err := dept.BeforeSave(db); err != nil {
+			respondError(w, http.StatusConflict, err.Error())
+			return
+		}
+		if err := dept.CheckCycle(db, dept.ParentID); err != nil {
+			respondError(w, http.StatusConflict, err.Error())
+			return
+		}
+
+		deptIDs, err := departmentSubtreeIDs(ScopedDB(r.Context(), db), dept.ID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "database error")
+			return
+		}
+
+		tx := db.Begin()
+		// SkipHooks - otherwise BeforeSave runs against the zero-valued
+		// &Department{}/&Employee{} passed to Model, not the matched rows,
+		// and its "name/full_name cannot be empty" checks abort every update.
+		hookless := tx.Session(&gorm.Session{SkipHooks: true})
+		if err := hookless.Unscoped().Model(&Department{}).Where("id IN ?", deptIDs).Update("deleted_at", nil).Error; err != nil {
+			tx.Rollback()
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if err := hookless.Unscoped().Model(&Employee{}).Where("department_id IN ?", deptIDs).Update("deleted_at", nil).Error; err != nil {
+			tx.Rollback()
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if err := tx.Commit().Error; err != nil {
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		dept.DeletedAt = gorm.DeletedAt{}
+		respondJSON(w, http.StatusOK, dept)
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	err = db.AutoMigrate(&Organization{}, &Department{}, &Employee{}, &Position{}, &EmployeeAssignment{}, &ArchiveEvent{})
+	assert.NoError(t, err)
+	return db
+}
+
+func requestWithOrg(req *http.Request, orgID int) *http.Request {
+	ctx := context.WithValue(req.Context(), organizationIDContextKey, orgID)
+	return req.WithContext(ctx)
+}
+
+func TestCreateDepartment(t *testing.T) {
+	db := setupTestDB(t)
+	handler := createDepartment(db)
+
+	body := CreateDepartmentRequest{Name: "IT"}
+	jsonBody, _ := json.Marshal(body)
+	req := httptest.NewRequest("POST", "/departments/", bytes.NewReader(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp Department
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "IT", resp.Name)
+}
+
+func TestGetDepartmentScopedToOrganization(t *testing.T) {
+	db := setupTestDB(t)
+	dept := Department{OrganizationID: 1, Name: "IT"}
+	assert.NoError(t, db.Create(&dept).Error)
+
+	handler := getDepartment(db)
+	req := httptest.NewRequest("GET", "/departments/"+strconv.Itoa(dept.ID), nil)
+	req.SetPathValue("id", strconv.Itoa(dept.ID))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, requestWithOrg(req, 2))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, requestWithOrg(req, 1))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestUpdateEmployeeRecordsAssignmentHistory(t *testing.T) {
+	db := setupTestDB(t)
+	deptA := Department{OrganizationID: 1, Name: "IT"}
+	deptB := Department{OrganizationID: 1, Name: "Sales"}
+	assert.NoError(t, db.Create(&deptA).Error)
+	assert.NoError(t, db.Create(&deptB).Error)
+	position := Position{Name: "Engineer"}
+	assert.NoError(t, db.Create(&position).Error)
+
+	createHandler := createEmployee(db)
+	body, _ := json.Marshal(CreateEmployeeRequest{FullName: "Jane Doe", PositionID: position.ID})
+	req := httptest.NewRequest("POST", "/departments/"+strconv.Itoa(deptA.ID)+"/employees/", bytes.NewReader(body))
+	req.SetPathValue("id", strconv.Itoa(deptA.ID))
+	w := httptest.NewRecorder()
+	createHandler.ServeHTTP(w, requestWithOrg(req, 1))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var emp Employee
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &emp))
+
+	updateHandler := updateEmployee(db)
+	updateBody, _ := json.Marshal(UpdateEmployeeRequest{DepartmentID: &deptB.ID})
+	updateReq := httptest.NewRequest("PATCH", "/employees/"+strconv.Itoa(emp.ID), bytes.NewReader(updateBody))
+	updateReq.SetPathValue("id", strconv.Itoa(emp.ID))
+	w = httptest.NewRecorder()
+	updateHandler.ServeHTTP(w, requestWithOrg(updateReq, 1))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	historyHandler := getEmployeeHistory(db)
+	historyReq := httptest.NewRequest("GET", "/employees/"+strconv.Itoa(emp.ID)+"/history", nil)
+	historyReq.SetPathValue("id", strconv.Itoa(emp.ID))
+	w = httptest.NewRecorder()
+	historyHandler.ServeHTTP(w, requestWithOrg(historyReq, 1))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var history []EmployeeAssignment
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+	assert.Len(t, history, 2)
+	assert.NotNil(t, history[0].EndedAt)
+	assert.Nil(t, history[1].EndedAt)
+}
+
+func TestArchiveDepartmentHidesFromGetAndRestoreUndoesIt(t *testing.T) {
+	db := setupTestDB(t)
+	parent := Department{OrganizationID: 1, Name: "Engineering"}
+	assert.NoError(t, db.Create(&parent).Error)
+	child := Department{OrganizationID: 1, Name: "Backend", ParentID: &parent.ID}
+	assert.NoError(t, db.Create(&child).Error)
+	position := Position{Name: "Engineer"}
+	assert.NoError(t, db.Create(&position).Error)
+	emp := Employee{OrganizationID: 1, DepartmentID: child.ID, FullName: "Jane Doe", PositionID: position.ID}
+	assert.NoError(t, db.Create(&emp).Error)
+
+	archiveHandler := archiveDepartment(db)
+	archiveBody, _ := json.Marshal(ArchiveDepartmentRequest{Reason: "reorg"})
+	archiveReq := httptest.NewRequest("POST", "/departments/"+strconv.Itoa(child.ID)+"/archive", bytes.NewReader(archiveBody))
+	archiveReq.SetPathValue("id", strconv.Itoa(child.ID))
+	w := httptest.NewRecorder()
+	archiveHandler.ServeHTTP(w, requestWithOrg(archiveReq, 1))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	getHandler := getDepartment(db)
+	getReq := httptest.NewRequest("GET", "/departments/"+strconv.Itoa(child.ID), nil)
+	getReq.SetPathValue("id", strconv.Itoa(child.ID))
+	w = httptest.NewRecorder()
+	getHandler.ServeHTTP(w, requestWithOrg(getReq, 1))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var archivedCount int64
+	assert.NoError(t, db.Model(&Employee{}).Where("id = ?", emp.ID).Count(&archivedCount).Error)
+	assert.Equal(t, int64(0), archivedCount)
+
+	restoreHandler := restoreDepartment(db)
+	restoreReq := httptest.NewRequest("POST", "/departments/"+strconv.Itoa(child.ID)+"/restore", nil)
+	restoreReq.SetPathValue("id", strconv.Itoa(child.ID))
+	w = httptest.NewRecorder()
+	restoreHandler.ServeHTTP(w, requestWithOrg(restoreReq, 1))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	getHandler.ServeHTTP(w, requestWithOrg(getReq, 1))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	assert.NoError(t, db.Model(&Employee{}).Where("id = ?", emp.ID).Count(&archivedCount).Error)
+	assert.Equal(t, int64(1), archivedCount)
+}
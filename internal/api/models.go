@@ -0,0 +1,223 @@
+package api
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Organization struct {
+	ID        int       `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"size:200;not null" json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Department struct {
+	ID             int            `gorm:"primaryKey" json:"id"`
+	OrganizationID int            `gorm:"not null;index" json:"organization_id"`
+	Name           string         `gorm:"size:200;not null" json:"name"`
+	ParentID       *int           `gorm:"index" json:"parent_id"`
+	CreatedAt      time.Time      `json:"created_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	// для построения дерева в памяти (не сохраняются в БД)
+	Children  []Department `gorm:"-" json:"children,omitempty"`
+	Employees []Employee   `gorm:"-" json:"employees,omitempty"`
+}
+
+type Employee struct {
+	ID             int            `gorm:"primaryKey" json:"id"`
+	OrganizationID int            `gorm:"not null;index" json:"organization_id"`
+	DepartmentID   int            `gorm:"not null;index" json:"department_id"`
+	FullName       string         `gorm:"size:200;not null" json:"full_name"`
+	PositionID     int            `gorm:"not null;index" json:"position_id"`
+	HiredAt        *time.Time     `json:"hired_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// ArchiveEvent records one archive action against a department or employee:
+// who archived it, when, and why. EntityType is "department" or "employee".
+type ArchiveEvent struct {
+	ID         int       `gorm:"primaryKey" json:"id"`
+	EntityType string    `gorm:"size:50;not null;index:idx_archive_events_entity" json:"entity_type"`
+	EntityID   int       `gorm:"not null;index:idx_archive_events_entity" json:"entity_id"`
+	ArchivedBy int       `gorm:"not null" json:"archived_by"`
+	ArchivedAt time.Time `json:"archived_at"`
+	Reason     string    `gorm:"size:500" json:"reason"`
+}
+
+// Position is a job title employees can be assigned to, such as "Backend
+// Engineer". Names are unique so handlers can look one up without creating
+// duplicates by accident.
+type Position struct {
+	ID          int       `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"size:200;not null;uniqueIndex" json:"name"`
+	Description string    `gorm:"size:500" json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// EmployeeAssignment records one stint of an employee in a department and
+// position. EndedAt is nil while the assignment is current; updateEmployee
+// closes it and opens a new row whenever the department or position changes.
+type EmployeeAssignment struct {
+	ID           int        `gorm:"primaryKey" json:"id"`
+	EmployeeID   int        `gorm:"not null;index" json:"employee_id"`
+	DepartmentID int        `gorm:"not null;index" json:"department_id"`
+	PositionID   int        `gorm:"not null;index" json:"position_id"`
+	StartedAt    time.Time  `json:"started_at"`
+	EndedAt      *time.Time `json:"ended_at"`
+}
+
+type User struct {
+	ID           int       `gorm:"primaryKey" json:"id"`
+	Username     string    `gorm:"size:100;uniqueIndex;not null" json:"username"`
+	PasswordHash string    `gorm:"size:100;not null" json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	Roles         []Role         `gorm:"many2many:user_roles;" json:"roles,omitempty"`
+	Organizations []Organization `gorm:"many2many:user_organizations;" json:"organizations,omitempty"`
+}
+
+type Role struct {
+	ID          int         `gorm:"primaryKey" json:"id"`
+	Name        string      `gorm:"size:100;uniqueIndex;not null" json:"name"`
+	Permissions Permissions `gorm:"type:jsonb" json:"permissions"`
+}
+
+// Permissions is a list of scoped permission strings such as
+// "departments:read", stored as a jsonb array.
+type Permissions []string
+
+func (p Permissions) Has(permission string) bool {
+	for _, perm := range p {
+		if perm == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Permissions) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+func (p *Permissions) Scan(value interface{}) error {
+	if value == nil {
+		*p = nil
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("permissions: unsupported scan type %T", value)
+		}
+		b = []byte(s)
+	}
+	return json.Unmarshal(b, p)
+}
+
+// Хуки GORM для валидации и тримминга
+func (d *Department) BeforeSave(tx *gorm.DB) error {
+	d.Name = strings.TrimSpace(d.Name)
+	if len(d.Name) == 0 {
+		return errors.New("name cannot be empty")
+	}
+	if len(d.Name) > 200 {
+		return errors.New("name too long (max 200)")
+	}
+	// проверка уникальности имени в пределах одного родителя и организации
+	var count int64
+	query := tx.Model(&Department{}).Where("organization_id = ? AND name = ?", d.OrganizationID, d.Name)
+	if d.ParentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *d.ParentID)
+	}
+	if d.ID != 0 {
+		query = query.Where("id != ?", d.ID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("department name must be unique within the same parent")
+	}
+	return nil
+}
+
+func (e *Employee) BeforeSave(tx *gorm.DB) error {
+	e.FullName = strings.TrimSpace(e.FullName)
+	if len(e.FullName) == 0 {
+		return errors.New("full_name cannot be empty")
+	}
+	if len(e.FullName) > 200 {
+		return errors.New("full_name too long (max 200)")
+	}
+	if e.PositionID == 0 {
+		return errors.New("position_id is required")
+	}
+	return nil
+}
+
+func (p *Position) BeforeSave(tx *gorm.DB) error {
+	p.Name = strings.TrimSpace(p.Name)
+	if len(p.Name) == 0 {
+		return errors.New("name cannot be empty")
+	}
+	if len(p.Name) > 200 {
+		return errors.New("name too long (max 200)")
+	}
+	var count int64
+	query := tx.Model(&Position{}).Where("name = ?", p.Name)
+	if p.ID != 0 {
+		query = query.Where("id != ?", p.ID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("position name must be unique")
+	}
+	return nil
+}
+
+// CheckCycle проверяет, не приведёт ли изменение parent_id к циклу
+func (d *Department) CheckCycle(db *gorm.DB, newParentID *int) error {
+	if newParentID == nil {
+		return nil
+	}
+	if *newParentID == d.ID {
+		return errors.New("cannot be parent of itself")
+	}
+	// проверяем, что новый родитель не является потомком текущего отдела (в пределах той же организации)
+	var childIDs []int
+	if err := db.Model(&Department{}).Where("organization_id = ? AND parent_id = ?", d.OrganizationID, d.ID).Pluck("id", &childIDs).Error; err != nil {
+		return err
+	}
+	queue := childIDs
+	visited := map[int]bool{d.ID: true}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		if current == *newParentID {
+			return errors.New("cannot move department inside its own subtree")
+		}
+		var children []int
+		if err := db.Model(&Department{}).Where("organization_id = ? AND parent_id = ?", d.OrganizationID, current).Pluck("id", &children).Error; err != nil {
+			return err
+		}
+		queue = append(queue, children...)
+	}
+	return nil
+}
\ No newline at end of file
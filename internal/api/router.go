@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NewRouter builds the simpleblog HTTP API: login plus the permission-gated
+// department/employee endpoints. It does not install the access-log
+// middleware - callers wrap the returned handler themselves so that request
+// logging stays a cross-cutting concern outside the router.
+func NewRouter(db *gorm.DB, jwtSecret []byte, jwtTTL time.Duration) http.Handler {
+	requirePermission := func(permission string, handler http.HandlerFunc) http.HandlerFunc {
+		return RequirePermission(db, jwtSecret, permission)(handler)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /auth/login", login(db, jwtSecret, jwtTTL))
+	mux.HandleFunc("POST /departments/", requirePermission("departments:write", createDepartment(db)))
+	mux.HandleFunc("POST /departments/{id}/employees/", requirePermission("employees:write", createEmployee(db)))
+	mux.HandleFunc("GET /departments/{id}", requirePermission("departments:read", getDepartment(db)))
+	mux.HandleFunc("PATCH /departments/{id}", requirePermission("departments:write", updateDepartment(db)))
+	mux.HandleFunc("DELETE /departments/{id}", requirePermission("departments:delete", deleteDepartment(db)))
+	mux.HandleFunc("POST /departments/{id}/archive", requirePermission("departments:archive", archiveDepartment(db)))
+	mux.HandleFunc("POST /departments/{id}/restore", requirePermission("departments:restore", restoreDepartment(db)))
+	mux.HandleFunc("PATCH /employees/{id}", requirePermission("employees:write", updateEmployee(db)))
+	mux.HandleFunc("GET /employees/{id}/history", requirePermission("employees:read", getEmployeeHistory(db)))
+	mux.HandleFunc("POST /positions/", requirePermission("positions:write", createPosition(db)))
+	mux.HandleFunc("GET /positions/", requirePermission("positions:read", listPositions(db)))
+	mux.HandleFunc("PATCH /positions/{id}", requirePermission("positions:write", updatePosition(db)))
+	mux.HandleFunc("DELETE /positions/{id}", requirePermission("positions:delete", deletePosition(db)))
+	return mux
+}
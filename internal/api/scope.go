@@ -0,0 +1,26 @@
+package api
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+const organizationIDContextKey contextKey = "organization_id"
+
+// ScopedDB returns db with a "WHERE organization_id = ?" clause for the
+// organization carried in ctx, so handlers can't forget to scope a query to
+// the caller's tenant.
+func ScopedDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return db.Where("organization_id = ?", organizationIDFromContext(ctx))
+}
+
+func organizationIDFromContext(ctx context.Context) int {
+	orgID, _ := ctx.Value(organizationIDContextKey).(int)
+	return orgID
+}
+
+func userIDFromContext(ctx context.Context) int {
+	userID, _ := ctx.Value(userIDContextKey).(int)
+	return userID
+}
@@ -0,0 +1,86 @@
+// Package config loads simpleblog's server configuration from a YAML file
+// via viper: connection and timeout settings for the HTTP server, the
+// database pool, and request logging.
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Config is the root of the YAML config file read by cmd/server.
+type Config struct {
+	Server   ServerConfig   `mapstructure:"server"`
+	Database DatabaseConfig `mapstructure:"database"`
+	Logging  LoggingConfig  `mapstructure:"logging"`
+}
+
+// ServerConfig controls how the HTTP server listens and shuts down.
+type ServerConfig struct {
+	Addr string `mapstructure:"addr"`
+
+	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+
+	// ShutdownGrace bounds how long the server waits for in-flight requests
+	// to finish after a SIGINT/SIGTERM before it gives up.
+	ShutdownGrace time.Duration `mapstructure:"shutdown_grace"`
+
+	// TLSCertFile/TLSKeyFile serve TLS from a static certificate pair.
+	// AutocertDomain, if set, takes precedence and serves TLS via
+	// golang.org/x/crypto/acme/autocert instead. Leaving all three empty
+	// serves plain HTTP.
+	TLSCertFile    string `mapstructure:"tls_cert_file"`
+	TLSKeyFile     string `mapstructure:"tls_key_file"`
+	AutocertDomain string `mapstructure:"autocert_domain"`
+}
+
+// DatabaseConfig controls the connection to Postgres.
+type DatabaseConfig struct {
+	DSN             string        `mapstructure:"dsn"`
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+}
+
+// LoggingConfig controls GORM's log verbosity and the access log format.
+type LoggingConfig struct {
+	// Level is "info" (log every query) or "silent" (GORM stays quiet).
+	Level string `mapstructure:"level"`
+	// Format is an accesslog format string; empty uses accesslog.CommonLogFormat.
+	Format string `mapstructure:"format"`
+}
+
+// Load reads and unmarshals the YAML config file at path, applying defaults
+// for anything it omits.
+func Load(path string) (*Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	setDefaults(v)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	return &cfg, nil
+}
+
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("server.addr", ":8080")
+	v.SetDefault("server.read_timeout", 5*time.Second)
+	v.SetDefault("server.write_timeout", 10*time.Second)
+	v.SetDefault("server.shutdown_grace", 15*time.Second)
+
+	v.SetDefault("database.dsn", "host=postgres user=postgres password=postgres dbname=organization port=5432 sslmode=disable TimeZone=UTC")
+	v.SetDefault("database.max_open_conns", 25)
+	v.SetDefault("database.max_idle_conns", 25)
+	v.SetDefault("database.conn_max_lifetime", 5*time.Minute)
+
+	v.SetDefault("logging.level", "info")
+}